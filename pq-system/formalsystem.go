@@ -0,0 +1,60 @@
+package main
+
+// State is what a FormalSystem's Lex produces from an input string: whether
+// it is well-formed, plus whatever system-specific data IsAxiom and
+// IsTheorem need in order to classify it further.
+type State struct {
+	Raw   string
+	Valid bool
+	Data  interface{}
+}
+
+// Rule names and describes one production rule of a formal system, for
+// display alongside its Table row.
+type Rule struct {
+	Name        string
+	Description string
+}
+
+// Step is one line of a derivation: the string a rule produced and a
+// human-readable note on which rule produced it.
+type Step struct {
+	Result string
+	Rule   string
+}
+
+// SystemInfo is the static, human-facing description of a FormalSystem, used
+// to drive the CLI's table headers.
+type SystemInfo struct {
+	Name    string
+	Columns []string
+}
+
+// FormalSystem is a formal system the way GEB presents them: a lexer that
+// recognizes well-formed strings, a notion of which strings are axioms, a
+// decision procedure for which are theorems, and the rules that justify a
+// theorem's derivation from an axiom.
+type FormalSystem interface {
+	Lex(s string) (State, error)
+	IsAxiom(st State) bool
+	IsTheorem(st State) bool
+	Rules() []Rule
+	Describe() SystemInfo
+
+	// Derive returns the full proof of s from an axiom, provided s is a
+	// theorem.
+	Derive(s string) ([]Step, error)
+	// Enumerate lists every theorem up to maxLen runes long.
+	Enumerate(maxLen int) <-chan string
+}
+
+// Systems lists the formal systems main's -system flag can select between.
+var Systems = map[string]func() FormalSystem{
+	"pq": func() FormalSystem {
+		return newLinearSystem("pq-System", 'p', 'q', func(x, y int) int { return x + y })
+	},
+	"tq": func() FormalSystem {
+		return newLinearSystem("tq-System", 't', 'q', func(x, y int) int { return x * y })
+	},
+	"miu": func() FormalSystem { return &miuSystem{} },
+}
@@ -0,0 +1,220 @@
+package main
+
+import "fmt"
+
+// eof signals that the lexer has run off the end of the input.
+const eof = -1
+
+// TokenType classifies the lexemes xyzLexer can emit.
+type TokenType int
+
+const (
+	TokenHyphen TokenType = iota
+	TokenLeft
+	TokenRight
+	TokenError
+)
+
+// Token is a lexeme paired with where it was found in the input, so that
+// errors point back at an exact position.
+type Token struct {
+	Type TokenType
+	Pos  int
+	Line int
+	Col  int
+	Val  string
+}
+
+// stateFn represents a state of xyzLexer as a function that returns the next
+// state. Driving the machine with a loop over stateFn, rather than the lexer
+// calling itself, keeps the stack flat no matter how long the input is.
+type stateFn func(*xyzLexer) stateFn
+
+// xyzPosition is which part of an x<left>y<right>z string the lexer is on.
+type xyzPosition int
+
+const (
+	posX xyzPosition = iota
+	posY
+	posZ
+)
+
+// xyzLexer scans strings of the shape x<left>y<right>z, where x, y, and z are
+// runs of hyphens and left/right are a system's two connector runes (p and q
+// for the pq-System, t and q for the tq-System). It is parameterized by those
+// connectors so both systems share one lexer.
+type xyzLexer struct {
+	input       string
+	start, pos  int
+	line, col   int
+	left, right rune
+	position    xyzPosition
+	valid       bool
+	tokens      []Token
+	err         *Token
+	x, y, z     int
+}
+
+// newXYZLexer initializes an xyzLexer for a system whose connectors are left
+// and right (e.g. 'p'/'q' or 't'/'q').
+func newXYZLexer(s string, left, right rune) *xyzLexer {
+	return &xyzLexer{input: s, left: left, right: right, line: 1}
+}
+
+// next returns the rune at the current position and advances past it.
+func (l *xyzLexer) next() rune {
+	if l.pos >= len(l.input) {
+		return eof
+	}
+
+	r := rune(l.input[l.pos])
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.col = 0
+	} else {
+		l.col++
+	}
+	return r
+}
+
+// backup steps the lexer back one rune. Can only be called once per call to next.
+func (l *xyzLexer) backup() {
+	l.pos--
+	if l.col > 0 {
+		l.col--
+	}
+}
+
+// peek returns the next rune without consuming it.
+func (l *xyzLexer) peek() rune {
+	r := l.next()
+	if r != eof {
+		l.backup()
+	}
+	return r
+}
+
+// emit appends the currently scanned lexeme as a Token of the given type.
+func (l *xyzLexer) emit(t TokenType) {
+	l.tokens = append(l.tokens, Token{
+		Type: t,
+		Pos:  l.start,
+		Line: l.line,
+		Col:  l.col,
+		Val:  l.input[l.start:l.pos],
+	})
+	l.start = l.pos
+}
+
+// Errorf records a positional error and returns nil to stop the state
+// machine. FormalSystem implementations surface the resulting Token so
+// callers can print diagnostics like "invalid rune 'x' at position 4" instead
+// of a bare Valid: false.
+func (l *xyzLexer) Errorf(format string, args ...interface{}) stateFn {
+	l.err = &Token{
+		Type: TokenError,
+		Pos:  l.start,
+		Line: l.line,
+		Col:  l.col,
+		Val:  fmt.Sprintf(format, args...),
+	}
+	l.valid = false
+	return nil
+}
+
+// Err returns the error produced during lexing, if any.
+func (l *xyzLexer) Err() *Token {
+	return l.err
+}
+
+// run drives the state machine to completion.
+func (l *xyzLexer) run() {
+	for state := stateFn(lexLeftHyphens); state != nil; {
+		state = state(l)
+	}
+}
+
+// lexHyphens consumes a run of zero or more hyphens into position, then hands
+// off to next.
+func (l *xyzLexer) lexHyphens(position xyzPosition, next stateFn) stateFn {
+	count := 0
+	for l.peek() == '-' {
+		l.next()
+		count++
+	}
+	if count > 0 {
+		l.emit(TokenHyphen)
+		switch position {
+		case posX:
+			l.x += count
+		case posY:
+			l.y += count
+		case posZ:
+			l.z += count
+		}
+	}
+	return next
+}
+
+// upper returns the uppercase form of an ASCII connector rune.
+func upper(r rune) rune {
+	if r >= 'a' && r <= 'z' {
+		return r - ('a' - 'A')
+	}
+	return r
+}
+
+// lexLeftHyphens consumes the hyphens before the left connector.
+func lexLeftHyphens(l *xyzLexer) stateFn {
+	return l.lexHyphens(posX, lexLeftConnector)
+}
+
+// lexLeftConnector expects and consumes the system's left connector rune.
+func lexLeftConnector(l *xyzLexer) stateFn {
+	r := l.next()
+	switch {
+	case r == l.left, r == upper(l.left):
+		l.emit(TokenLeft)
+		l.position = posY
+		return lexMiddleHyphens
+	case r == eof:
+		return l.Errorf("unexpected end of input at position %d, expected %q", l.pos, l.left)
+	default:
+		return l.Errorf("invalid rune %q at position %d", r, l.pos-1)
+	}
+}
+
+// lexMiddleHyphens consumes the hyphens between the two connectors.
+func lexMiddleHyphens(l *xyzLexer) stateFn {
+	return l.lexHyphens(posY, lexRightConnector)
+}
+
+// lexRightConnector expects and consumes the system's right connector rune.
+func lexRightConnector(l *xyzLexer) stateFn {
+	r := l.next()
+	switch {
+	case r == l.right, r == upper(l.right):
+		l.emit(TokenRight)
+		l.position = posZ
+		return lexTrailingHyphens
+	case r == eof:
+		return l.Errorf("unexpected end of input at position %d, expected %q", l.pos, l.right)
+	default:
+		return l.Errorf("invalid rune %q at position %d", r, l.pos-1)
+	}
+}
+
+// lexTrailingHyphens consumes the trailing hyphens and confirms nothing
+// follows them.
+func lexTrailingHyphens(l *xyzLexer) stateFn {
+	l.lexHyphens(posZ, nil)
+
+	r := l.next()
+	if r != eof {
+		return l.Errorf("invalid rune %q at position %d", r, l.pos-1)
+	}
+
+	l.valid = true
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// linearSystem implements FormalSystem for any formal system built from
+// strings x<left>y<right>z, where x, y, and z are hyphen counts and the
+// theorem relation is z == combine(x, y) for y >= 1. The pq-System and
+// tq-System are both instances of this shape: combine is addition for pq
+// (GEB ch.2) and multiplication for tq (GEB's exercise generalizing it).
+type linearSystem struct {
+	name        string
+	left, right rune
+	combine     func(x, y int) int
+}
+
+// newLinearSystem builds the FormalSystem for a pq/tq-shaped system whose
+// connectors are left and right and whose theorem relation is combine.
+func newLinearSystem(name string, left, right rune, combine func(x, y int) int) *linearSystem {
+	return &linearSystem{name: name, left: left, right: right, combine: combine}
+}
+
+// linearState is the Data a linearSystem stashes in a State: the x, y, z
+// hyphen counts the lexer found.
+type linearState struct {
+	x, y, z int
+}
+
+func (s *linearSystem) Lex(in string) (State, error) {
+	lexer := newXYZLexer(in, s.left, s.right)
+	lexer.run()
+
+	st := State{Raw: in, Valid: lexer.valid, Data: linearState{lexer.x, lexer.y, lexer.z}}
+	if !lexer.valid {
+		return st, fmt.Errorf("%s", lexer.Err().Val)
+	}
+	return st, nil
+}
+
+func (s *linearSystem) IsAxiom(st State) bool {
+	d, ok := st.Data.(linearState)
+	return st.Valid && ok && d.y == 1 && d.z == s.combine(d.x, 1)
+}
+
+func (s *linearSystem) IsTheorem(st State) bool {
+	d, ok := st.Data.(linearState)
+	return st.Valid && ok && d.y >= 1 && d.z == s.combine(d.x, d.y)
+}
+
+func (s *linearSystem) Rules() []Rule {
+	return []Rule{
+		{
+			Name:        "axiom schema",
+			Description: fmt.Sprintf("x%c-%cx, for any x composed only of hyphens", s.left, s.right),
+		},
+		{
+			Name: "production rule",
+			Description: fmt.Sprintf(
+				"if x%cy%cz is a theorem, so is x%cy-%cz', where z' makes x%cy-%cz' a theorem in turn",
+				s.left, s.right, s.left, s.right, s.left, s.right,
+			),
+		},
+	}
+}
+
+func (s *linearSystem) Describe() SystemInfo {
+	return SystemInfo{Name: s.name, Columns: []string{"Valid", "Axiom", "Theorem"}}
+}
+
+// render renders an x<left>y<right>z string from its hyphen counts.
+func (s *linearSystem) render(x, y, z int) string {
+	return strings.Repeat("-", x) + string(s.left) + strings.Repeat("-", y) + string(s.right) + strings.Repeat("-", z)
+}
+
+func (s *linearSystem) Derive(in string) ([]Step, error) {
+	st, err := s.Lex(in)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsTheorem(st) {
+		return nil, fmt.Errorf("%q is not a theorem", in)
+	}
+
+	d := st.Data.(linearState)
+
+	steps := []Step{{
+		Result: s.render(d.x, 1, s.combine(d.x, 1)),
+		Rule:   fmt.Sprintf("axiom schema (x=%d)", d.x),
+	}}
+
+	for y := 2; y <= d.y; y++ {
+		steps = append(steps, Step{
+			Result: s.render(d.x, y, s.combine(d.x, y)),
+			Rule:   fmt.Sprintf("production rule (x=%d, y=%d)", d.x, y),
+		})
+	}
+
+	return steps, nil
+}
+
+// Enumerate performs a breadth-first search over the production rule,
+// starting from every axiom (x, y=1) that fits within maxLen, keyed on the
+// (x, y) counts that determine each string (z always follows from combine).
+func (s *linearSystem) Enumerate(maxLen int) <-chan string {
+	out := make(chan string)
+
+	type counts struct{ x, y int }
+
+	go func() {
+		defer close(out)
+
+		visited := make(map[counts]bool)
+		var queue []counts
+
+		for x := 0; len(s.render(x, 1, s.combine(x, 1))) <= maxLen; x++ {
+			c := counts{x, 1}
+			visited[c] = true
+			queue = append(queue, c)
+		}
+
+		for len(queue) > 0 {
+			c := queue[0]
+			queue = queue[1:]
+
+			out <- s.render(c.x, c.y, s.combine(c.x, c.y))
+
+			next := counts{c.x, c.y + 1}
+			if !visited[next] && len(s.render(next.x, next.y, s.combine(next.x, next.y))) <= maxLen {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}()
+
+	return out
+}
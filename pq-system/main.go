@@ -1,162 +1,70 @@
-// Decision Procedure for the pq-System as described in Gödel, Escher, Bach ch.2.
-// Usage: pqs.exe ...strings where "...strings" is a list of possible theorems.
-// Returns a table of the decisions made for each input string, including whether
-// the input is an axiom or a theorem. Additionally, reports invalid inputs.
-
-// The axiom schema of pq-System is xp-qx- whenever x is composed of hyphens only
-// (and each x must stand for the same string).
-
-// The only rule of production in pq-System is as follows: `Suppose x, y, and z
-// all stand for particular strings containing only hyphens. And suppose that
-// 'xpyqz' is known to be a theorem. Then 'xpy-qz-' is a theorem`.
+// Decision procedure for the formal systems Gödel, Escher, Bach builds up in
+// its early chapters: the pq-System and tq-System (ch.2, representing
+// addition and multiplication) and the MIU-system (ch.1).
+// Usage: pqs.exe [-system {pq,tq,miu}] [-derive] [--] ...strings where
+// "...strings" is a list of possible theorems (use "--" before strings that
+// start with a hyphen so they aren't parsed as flags). Returns a table of the
+// decisions made for each input string, including whether it is an axiom or a
+// theorem. Additionally, reports invalid inputs. Pass -derive to also print
+// the proof for each theorem found.
+// Usage: pqs.exe -system s -enumerate N instead lists every theorem of system
+// s up to N runes long.
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 )
 
-// Position represents which part of the input string we are on: before the p, in
-// between the p and the q, or after the q.
-type Position int
-
-const (
-	LeftOfP Position = iota
-	Center
-	RightOfQ
-)
-
-// Lexer does all the heavy lifting. We read through a string and maintain our
-// place here like in a grammatical lexer.
-type Lexer struct {
-	input    string
-	place    int
-	position Position
-	valid    bool
-	// leftOfP, betweenPAndQ, and rightOfQ represent the count of hyphens in each position
-	leftOfP      int
-	betweenPAndQ int
-	rightOfQ     int
-}
-
-// Token is a shorthand for working with the possible runes in an easier way. On
-// matching a rune to a character, we return the Token instead of the rune.
-type Token int
-
-const (
-	TokenP Token = iota
-	TokenQ
-	TokenHyphen
-
-	// TokenDone and TokenUnknown aren't valid runes but instead signify valuable
-	// information to the lexer that we can't keep lexing.
-	TokenDone
-	TokenUnknown
-)
-
-// advance moves our place in the input string up by 1
-func (l *Lexer) advance() {
-	l.place++
-}
-
-// retreat moves our place in the input string down by 1
-func (l *Lexer) retreat() {
-	l.place--
-}
-
-// munch processes the **current** token and, in most cases, will advance our place by 1.
-func (l *Lexer) munch() Token {
-	defer l.advance()
-	if l.place == len(l.input) {
-		return TokenDone
-	}
-
-	r := rune(l.input[l.place])
-	switch r {
-	case 'p':
-		fallthrough
-	case 'P':
-		return TokenP
-	case 'q':
-		fallthrough
-	case 'Q':
-		return TokenQ
-	case '-':
-		return TokenHyphen
-	default:
-		// Retreat our place: the earlier defer advance will increment our place even
-		// though we didn't lex the current token.
-		defer l.retreat()
-		return TokenUnknown
+func main() {
+	system := flag.String("system", "pq", "formal system to use: pq, tq, or miu")
+	derive := flag.Bool("derive", false, "print the derivation under each theorem found")
+	enumerate := flag.Int("enumerate", 0, "list every theorem up to this many runes instead of deciding inputs")
+	flag.Parse()
+
+	newSystem, ok := Systems[*system]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown -system %q, want one of pq, tq, miu\n", *system)
+		os.Exit(1)
 	}
-}
-
-// process will munch through the entire input string, counting hyphens and
-// updating the lexer's state as appropriate
-func (l *Lexer) process() {
-	token := l.munch()
+	fs := newSystem()
 
-	switch token {
-	case TokenDone:
-		l.valid = true
-		return
-	case TokenUnknown:
-		l.valid = false
-		return
-	case TokenHyphen:
-		switch l.position {
-		case LeftOfP:
-			l.leftOfP++
-		case Center:
-			l.betweenPAndQ++
-		case RightOfQ:
-			l.rightOfQ++
+	if *enumerate > 0 {
+		for s := range fs.Enumerate(*enumerate) {
+			fmt.Println(s)
 		}
-	case TokenP:
-		l.position = Center
-	case TokenQ:
-		l.position = RightOfQ
-	}
-
-	l.process()
-}
-
-// isAxiom verifies the axiom schema set out above by using the hyphen counts
-func (l *Lexer) isAxiom() bool {
-	return l.valid && l.leftOfP+1 == l.rightOfQ
-}
-
-// isTheorem verifies that this string is a theorem by using the hyphen counts
-func (l *Lexer) isTheorem() bool {
-	// all axioms are theorems
-	if l.isAxiom() {
-		return true
+		return
 	}
 
-	return l.valid && l.leftOfP+l.betweenPAndQ == l.rightOfQ
-}
-
-// NewLexer properly initializes a new Lexer from an input string
-func NewLexer(s string) Lexer {
-	return Lexer{input: s}
-}
-
-func main() {
 	// input strings should be passed in as space separated strings
-	strings := os.Args[1:]
-	table := &Table{inputBorder: 7, headers: []string{"Input No.", "Valid", "Axiom", "Theorem", "Input"}}
-
-	for n, s := range strings {
-		lexer := NewLexer(s)
-		lexer.process()
+	inputs := flag.Args()
+
+	info := fs.Describe()
+	headers := append([]string{"Input No."}, info.Columns...)
+	headers = append(headers, "Input")
+	table := &Table{inputBorder: 7, headers: headers}
+
+	var errs []string
+	var proofs []string
+	for n, s := range inputs {
+		st, err := fs.Lex(s)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("input #%d %q: %s", n+1, s, err))
+		}
 
 		// if the input string is very long, we extend the border of our table to match
-		if len(lexer.input)+2 > table.inputBorder {
-			table.inputBorder = len(lexer.input) + 2
+		if len(s)+2 > table.inputBorder {
+			table.inputBorder = len(s) + 2
 		}
 
 		// add the data of this input string to our table
-		table.entries = append(table.entries, n+1, lexer.valid, lexer.isAxiom(), lexer.isTheorem(), lexer.input)
+		table.entries = append(table.entries, n+1, st.Valid, fs.IsAxiom(st), fs.IsTheorem(st), s)
+
+		if *derive && fs.IsTheorem(st) {
+			proofs = append(proofs, formatProof(fs, n+1, s))
+		}
 	}
 
 	// pretty print the header, border, and data of the result table
@@ -165,6 +73,30 @@ func main() {
 	table.PrintBorder("├", "┤", "─")
 	table.PrintEntries("│")
 	table.PrintBorder("└", "┘", "─")
+
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+
+	for _, p := range proofs {
+		fmt.Println(p)
+	}
+}
+
+// formatProof derives the proof for the n'th input string and renders it as
+// the block of text main prints below the table when -derive is set.
+func formatProof(fs FormalSystem, n int, s string) string {
+	steps, err := fs.Derive(s)
+	if err != nil {
+		return fmt.Sprintf("input #%d %q: %s", n, s, err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "input #%d %q is a theorem:\n", n, s)
+	for i, st := range steps {
+		fmt.Fprintf(&b, "  %d. %s: %s\n", i+1, st.Rule, st.Result)
+	}
+	return strings.TrimRight(b.String(), "\n")
 }
 
 // Table is used exclusively for formatting the results
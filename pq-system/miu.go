@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// miuSearchLimit bounds the string length miuSystem's breadth-first search
+// will explore. MIU's four rules can grow a string without bound (rule II
+// doubles it), so unlike the pq/tq-systems there is no closed-form decision
+// procedure for theoremhood here -- that absence is the whole point of GEB
+// ch.1. IsTheorem and Derive are therefore a best-effort bounded search
+// rather than an instant decision.
+const miuSearchLimit = 12
+
+// miuSystem implements FormalSystem for the MIU-system from GEB ch.1: the
+// alphabet {M, I, U}, the single axiom "MI", and four string-rewrite rules.
+type miuSystem struct{}
+
+// miuState is the Data a miuSystem stashes in a State: the raw symbol string.
+type miuState struct {
+	symbols string
+}
+
+func (s *miuSystem) Lex(in string) (State, error) {
+	for i, r := range in {
+		if r != 'M' && r != 'I' && r != 'U' {
+			return State{Raw: in}, fmt.Errorf("invalid rune %q at position %d", r, i)
+		}
+	}
+	return State{Raw: in, Valid: true, Data: miuState{symbols: in}}, nil
+}
+
+func (s *miuSystem) IsAxiom(st State) bool {
+	d, ok := st.Data.(miuState)
+	return st.Valid && ok && d.symbols == "MI"
+}
+
+func (s *miuSystem) IsTheorem(st State) bool {
+	d, ok := st.Data.(miuState)
+	if !st.Valid || !ok {
+		return false
+	}
+	_, found := miuSearch(d.symbols)
+	return found
+}
+
+func (s *miuSystem) Rules() []Rule {
+	return []Rule{
+		{Name: "rule I", Description: "xI -> xIU: a trailing I may be followed by a U"},
+		{Name: "rule II", Description: "Mx -> Mxx: everything after the M may be doubled"},
+		{Name: "rule III", Description: "xIIIy -> xUy: three consecutive I's may become a U"},
+		{Name: "rule IV", Description: "xUUy -> xy: two consecutive U's may be dropped"},
+	}
+}
+
+func (s *miuSystem) Describe() SystemInfo {
+	return SystemInfo{Name: "MIU-system", Columns: []string{"Valid", "Axiom", "Theorem"}}
+}
+
+func (s *miuSystem) Derive(in string) ([]Step, error) {
+	st, err := s.Lex(in)
+	if err != nil {
+		return nil, err
+	}
+
+	path, found := miuSearch(st.Data.(miuState).symbols)
+	if !found {
+		return nil, fmt.Errorf("%q is not a theorem within %d runes", in, miuSearchLimit)
+	}
+
+	steps := make([]Step, len(path))
+	steps[0] = Step{Result: path[0], Rule: "axiom"}
+	for i := 1; i < len(path); i++ {
+		steps[i] = Step{Result: path[i], Rule: fmt.Sprintf("rewrite of %q", path[i-1])}
+	}
+	return steps, nil
+}
+
+// Enumerate lists every string reachable from the axiom MI by the four
+// rules, up to maxLen runes, in breadth-first order.
+func (s *miuSystem) Enumerate(maxLen int) <-chan string {
+	out := make(chan string)
+
+	go func() {
+		defer close(out)
+
+		visited := map[string]bool{"MI": true}
+		queue := []string{"MI"}
+
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			if len(cur) > maxLen {
+				continue
+			}
+			out <- cur
+
+			for _, next := range miuApplyRules(cur) {
+				if !visited[next] && len(next) <= maxLen {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// miuSearch breadth-first searches from the axiom MI for target, bounded by
+// miuSearchLimit, returning the path of strings from axiom to target.
+func miuSearch(target string) ([]string, bool) {
+	if len(target) > miuSearchLimit {
+		return nil, false
+	}
+
+	type node struct {
+		symbols string
+		path    []string
+	}
+
+	if target == "MI" {
+		return []string{"MI"}, true
+	}
+
+	visited := map[string]bool{"MI": true}
+	queue := []node{{symbols: "MI", path: []string{"MI"}}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, next := range miuApplyRules(cur.symbols) {
+			if len(next) > miuSearchLimit || visited[next] {
+				continue
+			}
+			path := append(append([]string{}, cur.path...), next)
+			if next == target {
+				return path, true
+			}
+			visited[next] = true
+			queue = append(queue, node{symbols: next, path: path})
+		}
+	}
+
+	return nil, false
+}
+
+// miuApplyRules returns every string reachable from s by one application of
+// one of the four MIU rules. Rules III and IV are applied at every matching
+// index, not just the first: e.g. MIIIIU contains "III" at both index 1
+// ("MIII" + "IU") and index 2 ("MI" + "III" + "U"), and only the second
+// rewrite (-> MIUU) is on a path to some theorems, so skipping it would miss
+// real theorems.
+func miuApplyRules(s string) []string {
+	var out []string
+
+	if strings.HasSuffix(s, "I") {
+		out = append(out, s+"U")
+	}
+
+	if strings.HasPrefix(s, "M") {
+		rest := s[1:]
+		out = append(out, "M"+rest+rest)
+	}
+
+	for i := 0; i+3 <= len(s); i++ {
+		if s[i:i+3] == "III" {
+			out = append(out, s[:i]+"U"+s[i+3:])
+		}
+	}
+
+	for i := 0; i+2 <= len(s); i++ {
+		if s[i:i+2] == "UU" {
+			out = append(out, s[:i]+s[i+2:])
+		}
+	}
+
+	return out
+}